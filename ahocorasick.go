@@ -0,0 +1,159 @@
+package goaway
+
+// acKind identifies which dictionary a terminal node of the automaton belongs to.
+type acKind uint8
+
+const (
+	acKindNone acKind = iota
+	acKindProfanity
+	acKindFalsePositive
+	acKindFalseNegative
+)
+
+// acNode is a single state of the Aho-Corasick automaton.
+type acNode struct {
+	children map[rune]*acNode
+	fail     *acNode
+	kind     acKind
+	word     string
+	depth    int // number of runes from the root to this node
+}
+
+func newACNode() *acNode {
+	return &acNode{children: make(map[rune]*acNode)}
+}
+
+// acMatch is a single occurrence found while scanning, expressed as a rune span ([start, end)) into the
+// string that was scanned.
+type acMatch struct {
+	word  string
+	kind  acKind
+	start int
+	end   int
+}
+
+// ahoCorasick is a multi-pattern string matching automaton built once per ProfanityDetector (and rebuilt
+// whenever its dictionaries change) so that scanning an input for every profanity, false positive and
+// false negative is a single O(n) pass over the input instead of O(n*|dictionary|) strings.Contains calls.
+type ahoCorasick struct {
+	root *acNode
+}
+
+// newAhoCorasick builds an automaton over profanities, falsePositives and falseNegatives, tagging each
+// terminal node with which list it came from.
+func newAhoCorasick(profanities, falsePositives, falseNegatives []string) *ahoCorasick {
+	ac := &ahoCorasick{root: newACNode()}
+	for _, word := range profanities {
+		ac.insert(word, acKindProfanity)
+	}
+	for _, word := range falsePositives {
+		ac.insert(word, acKindFalsePositive)
+	}
+	for _, word := range falseNegatives {
+		ac.insert(word, acKindFalseNegative)
+	}
+	ac.buildFailureLinks()
+	return ac
+}
+
+func (ac *ahoCorasick) insert(word string, kind acKind) {
+	if word == "" {
+		return
+	}
+	node := ac.root
+	depth := 0
+	for _, r := range word {
+		depth++
+		child, ok := node.children[r]
+		if !ok {
+			child = newACNode()
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.kind = kind
+	node.word = word
+	node.depth = depth
+}
+
+// buildFailureLinks computes, for every node, the longest proper suffix of its path that is also a path
+// from the root, via a BFS over the trie.
+func (ac *ahoCorasick) buildFailureLinks() {
+	queue := make([]*acNode, 0, len(ac.root.children))
+	for _, child := range ac.root.children {
+		child.fail = ac.root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for r, child := range node.children {
+			fail := node.fail
+			for fail != nil {
+				if next, ok := fail.children[r]; ok {
+					child.fail = next
+					break
+				}
+				fail = fail.fail
+			}
+			if child.fail == nil {
+				child.fail = ac.root
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// scan walks s once, following goto transitions and falling back through failure links, and returns every
+// match found along the way (in the order their end position is reached).
+func (ac *ahoCorasick) scan(s string) []acMatch {
+	var matches []acMatch
+	node := ac.root
+	runeIndex := 0
+	for _, r := range s {
+		for node != ac.root {
+			if _, ok := node.children[r]; ok {
+				break
+			}
+			node = node.fail
+		}
+		if next, ok := node.children[r]; ok {
+			node = next
+		}
+		for n := node; n != nil && n != ac.root; n = n.fail {
+			if n.kind != acKindNone {
+				matches = append(matches, acMatch{
+					word:  n.word,
+					kind:  n.kind,
+					start: runeIndex + 1 - n.depth,
+					end:   runeIndex + 1,
+				})
+			}
+		}
+		runeIndex++
+	}
+	return matches
+}
+
+// spansOfKind extracts the [start, end) rune spans of every match of the given kind.
+func spansOfKind(matches []acMatch, kind acKind) [][2]int {
+	var spans [][2]int
+	for _, m := range matches {
+		if m.kind == kind {
+			spans = append(spans, [2]int{m.start, m.end})
+		}
+	}
+	return spans
+}
+
+// isWholeToken reports whether the sanitized rune span [start, end) is bounded by spaces (or the start/end
+// of the string) on both sides, which is what WithExactWord requires of a profanity match.
+func isWholeToken(sanitized []rune, start, end int) bool {
+	if start > 0 && sanitized[start-1] != ' ' {
+		return false
+	}
+	if end < len(sanitized) && sanitized[end] != ' ' {
+		return false
+	}
+	return true
+}
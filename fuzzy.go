@@ -0,0 +1,125 @@
+package goaway
+
+import "strings"
+
+// minFuzzyTokenLength is the shortest token WithFuzzyMatching will ever consider, to avoid trivially
+// matching short, common words against short dictionary entries.
+const minFuzzyTokenLength = 4
+
+// scanAll combines the Aho-Corasick automaton's matches with fuzzy matches (when WithFuzzyMatching is
+// enabled), so callers only need to deal with one stream of acMatch values.
+func (g *ProfanityDetector) scanAll(sanitized string) []acMatch {
+	matches := g.automaton.scan(sanitized)
+	if g.fuzzyMatching {
+		matches = append(matches, g.findFuzzyMatches(sanitized)...)
+	}
+	return matches
+}
+
+// findFuzzyMatches tokenizes sanitized on spaces and reports, for each token within maxDistance of a
+// profanity, an acMatch spanning that token. It requires WithSanitizeSpaces(false), which WithFuzzyMatching
+// sets automatically, so tokens remain intact in sanitized.
+func (g *ProfanityDetector) findFuzzyMatches(sanitized string) []acMatch {
+	var matches []acMatch
+	runes := []rune(sanitized)
+	tokenStart := 0
+	for i := 0; i <= len(runes); i++ {
+		if i == len(runes) || runes[i] == ' ' {
+			if i > tokenStart {
+				token := string(runes[tokenStart:i])
+				if word, ok := g.bestFuzzyMatch(token); ok {
+					matches = append(matches, acMatch{word: word, kind: acKindProfanity, start: tokenStart, end: i})
+				}
+			}
+			tokenStart = i + 1
+		}
+	}
+	return matches
+}
+
+// bestFuzzyMatch returns the first dictionary profanity within g.fuzzyMaxDistance of token, or false if
+// none is close enough. Tokens on the false-positive list are skipped before scoring so that, e.g., a word
+// that's merely a near-miss of a profanity but explicitly allow-listed is never flagged.
+func (g *ProfanityDetector) bestFuzzyMatch(token string) (string, bool) {
+	tokenRuneLen := len([]rune(token))
+	if tokenRuneLen < minFuzzyTokenLength {
+		return "", false
+	}
+	if containsWordFold(g.falsePositives, token) {
+		return "", false
+	}
+	for _, word := range g.profanities {
+		wordRuneLen := len([]rune(word))
+		if abs(tokenRuneLen-wordRuneLen) > g.fuzzyMaxDistance {
+			continue
+		}
+		if tokenRuneLen < wordRuneLen-g.fuzzyMaxDistance {
+			continue
+		}
+		if levenshtein(token, word, g.fuzzyMaxDistance) <= g.fuzzyMaxDistance {
+			return word, true
+		}
+	}
+	return "", false
+}
+
+func containsWordFold(words []string, token string) bool {
+	for _, word := range words {
+		if strings.EqualFold(word, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// levenshtein computes the Levenshtein edit distance between a and b, using two rolling rows of size
+// min(len(a), len(b))+1 rather than a full O(len(a)*len(b)) table. It exits early once every entry in a row
+// exceeds maxDistance, since the true distance can then only be larger, returning maxDistance+1 in that
+// case rather than the exact distance.
+func levenshtein(a, b string, maxDistance int) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) > len(rb) {
+		ra, rb = rb, ra
+	}
+	prev := make([]int, len(ra)+1)
+	curr := make([]int, len(ra)+1)
+	for i := range prev {
+		prev[i] = i
+	}
+	for j := 1; j <= len(rb); j++ {
+		curr[0] = j
+		rowMin := curr[0]
+		for i := 1; i <= len(ra); i++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[i] = min3(prev[i]+1, curr[i-1]+1, prev[i-1]+cost)
+			if curr[i] < rowMin {
+				rowMin = curr[i]
+			}
+		}
+		if rowMin > maxDistance {
+			return maxDistance + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(ra)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
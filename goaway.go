@@ -1,6 +1,7 @@
 package goaway
 
 import (
+	"sort"
 	"strings"
 	"unicode"
 
@@ -33,11 +34,35 @@ type ProfanityDetector struct {
 	falsePositives []string
 
 	characterReplacements map[rune]rune
+
+	// firstSupportedRune and lastSupportedRune bound the range of runes removeAccents assumes need no
+	// special handling. They default to firstRuneSupported/lastRuneSupported (the Latin range) and are
+	// widened by WithLocales so that, e.g., Cyrillic text doesn't run the accent-removal transform on
+	// every single character.
+	firstSupportedRune rune
+	lastSupportedRune  rune
+	// preservedAccents are runes removeAccents must never strip, because a locale considers them letters
+	// in their own right rather than accented variants of a base letter (e.g. Portuguese "ç", "ã").
+	preservedAccents map[rune]bool
+
+	fuzzyMatching    bool
+	fuzzyMaxDistance int
+
+	replacementCharacter rune
+	replaceWholeWord     bool
+	// obfuscationLength caps how many consecutive special characters (those characterReplacements maps to
+	// ' ') the sanitizer will collapse between two kept letters; a negative value means no cap. See
+	// WithObfuscationLength.
+	obfuscationLength int
+
+	automaton *ahoCorasick
 }
 
-// NewProfanityDetector creates a new ProfanityDetector
-func NewProfanityDetector() *ProfanityDetector {
-	return &ProfanityDetector{
+// NewProfanityDetector creates a new ProfanityDetector. Locales can be passed in to merge their
+// dictionaries and replacement tables on top of the English defaults, e.g.
+// NewProfanityDetector(pt.Locale, ru.Locale); WithLocales can be used to the same effect afterwards.
+func NewProfanityDetector(locales ...Locale) *ProfanityDetector {
+	g := &ProfanityDetector{
 		sanitizeSpecialCharacters: true,
 		sanitizeLeetSpeak:         true,
 		sanitizeAccents:           true,
@@ -47,7 +72,21 @@ func NewProfanityDetector() *ProfanityDetector {
 		falsePositives:            DefaultFalsePositives,
 		falseNegatives:            DefaultFalseNegatives,
 		characterReplacements:     DefaultCharacterReplacements,
+		firstSupportedRune:        firstRuneSupported,
+		lastSupportedRune:         lastRuneSupported,
+		replacementCharacter:      '*',
+		obfuscationLength:         -1,
 	}
+	g.applyLocales(locales)
+	return g.buildAutomaton()
+}
+
+// buildAutomaton (re)builds the Aho-Corasick automaton used to scan for profanities, false positives and
+// false negatives in a single pass. It must be called whenever profanities, falsePositives or
+// falseNegatives change.
+func (g *ProfanityDetector) buildAutomaton() *ProfanityDetector {
+	g.automaton = newAhoCorasick(g.profanities, g.falsePositives, g.falseNegatives)
+	return g
 }
 
 // WithSanitizeLeetSpeak allows configuring whether the sanitization process should also take into account leetspeak
@@ -97,7 +136,63 @@ func (g *ProfanityDetector) WithCustomDictionary(profanities, falsePositives, fa
 	g.profanities = profanities
 	g.falsePositives = falsePositives
 	g.falseNegatives = falseNegatives
-	return g
+	return g.buildAutomaton()
+}
+
+// WithLocales merges one or more Locale dictionaries and replacement tables into the detector, on top of
+// whatever dictionary is already configured (the English defaults, or a prior WithCustomDictionary).
+func (g *ProfanityDetector) WithLocales(locales ...Locale) *ProfanityDetector {
+	g.applyLocales(locales)
+	return g.buildAutomaton()
+}
+
+// applyLocales merges each locale's dictionaries, replacement table, preserved accents and supported rune
+// range into g. It clones the dictionary slices and the replacement map before writing to them so that it
+// never mutates a shared value such as DefaultCharacterReplacements; the preserved-accent map is created
+// per detector on first use and written in place.
+func (g *ProfanityDetector) applyLocales(locales []Locale) {
+	if len(locales) == 0 {
+		return
+	}
+	g.profanities = append([]string(nil), g.profanities...)
+	g.falsePositives = append([]string(nil), g.falsePositives...)
+	g.falseNegatives = append([]string(nil), g.falseNegatives...)
+	g.characterReplacements = cloneRuneMap(g.characterReplacements)
+
+	for _, locale := range locales {
+		g.profanities = append(g.profanities, locale.Profanities...)
+		g.falsePositives = append(g.falsePositives, locale.FalsePositives...)
+		g.falseNegatives = append(g.falseNegatives, locale.FalseNegatives...)
+
+		for from, to := range locale.CharacterReplacements {
+			g.characterReplacements[from] = to
+		}
+
+		if len(locale.AccentedLetters) > 0 {
+			if g.preservedAccents == nil {
+				g.preservedAccents = make(map[rune]bool, len(locale.AccentedLetters))
+			}
+			for _, r := range locale.AccentedLetters {
+				g.preservedAccents[r] = true
+			}
+		}
+
+		if locale.FirstRune != 0 && locale.FirstRune < g.firstSupportedRune {
+			g.firstSupportedRune = locale.FirstRune
+		}
+		if locale.LastRune != 0 && locale.LastRune > g.lastSupportedRune {
+			g.lastSupportedRune = locale.LastRune
+		}
+	}
+}
+
+// cloneRuneMap returns a shallow copy of m, so callers can mutate the result without affecting m itself.
+func cloneRuneMap(m map[rune]rune) map[rune]rune {
+	clone := make(map[rune]rune, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
 }
 
 // WithCustomCharacterReplacements allows configuring characters that to be replaced by other characters.
@@ -121,6 +216,48 @@ func (g *ProfanityDetector) WithExactWord(exactWord bool) *ProfanityDetector {
 	return g.WithSanitizeSpaces(false)
 }
 
+// WithFuzzyMatching allows catching obfuscations the character-replacement table doesn't cover, such as
+// deliberate misspellings ("fukk", "azzhole", "shiiit") or inserted junk letters, by matching tokens against
+// the dictionary with a bounded Levenshtein distance instead of requiring an exact substring.
+//
+// After sanitization, each token of at least 4 runes, split on ' ' only, is compared against every
+// profanity whose length is within maxDistance of the token's; a token matches if its edit distance to the
+// word is at most maxDistance. Shorter tokens are never matched. Words on the false-positive list are
+// skipped before scoring.
+//
+// Note: this entails also setting WithSanitizeSpaces(false), since fuzzy matching tokenizes on spaces.
+func (g *ProfanityDetector) WithFuzzyMatching(maxDistance int) *ProfanityDetector {
+	g.fuzzyMatching = true
+	g.fuzzyMaxDistance = maxDistance
+	return g.WithSanitizeSpaces(false)
+}
+
+// WithReplacementCharacter allows configuring the rune Censor uses to mask profanities. Defaults to '*'.
+func (g *ProfanityDetector) WithReplacementCharacter(r rune) *ProfanityDetector {
+	g.replacementCharacter = r
+	return g
+}
+
+// WithReplaceWholeWord allows configuring whether Censor replaces a whole matched word with a
+// fixed-length mask (of WithReplacementCharacter repeated 4 times) instead of masking one rune at a time.
+// This is useful for UIs that don't want to leak the length of the censored profanity.
+//
+// By default, this is set to false.
+func (g *ProfanityDetector) WithReplaceWholeWord(replaceWholeWord bool) *ProfanityDetector {
+	g.replaceWholeWord = replaceWholeWord
+	return g
+}
+
+// WithObfuscationLength caps how many consecutive special characters (those characterReplacements maps to
+// ' ') the sanitizer will collapse between two kept letters; leet speak characters count as kept letters. For instance, with n=1, "f_u_c_k" still sanitizes to "fuck" and gets censored,
+// but "f___u___c___k" has runs of 3 filler characters between letters and is left alone.
+//
+// A negative n (the default) means there is no cap, matching the previous behavior.
+func (g *ProfanityDetector) WithObfuscationLength(n int) *ProfanityDetector {
+	g.obfuscationLength = n
+	return g
+}
+
 // IsProfane takes in a string (word or sentence) and look for profanities.
 // Returns a boolean
 func (g *ProfanityDetector) IsProfane(s string) bool {
@@ -132,105 +269,123 @@ func (g *ProfanityDetector) IsProfane(s string) bool {
 func (g *ProfanityDetector) ExtractProfanity(s string) string {
 	s, _ = g.sanitize(s, false)
 
-	// Check for false negatives
-	for _, word := range g.falseNegatives {
-		if match := strings.Contains(s, word); match {
-			return word
+	matches := g.scanAll(s)
+
+	// False negatives always count, regardless of false positives.
+	for _, m := range matches {
+		if m.kind == acKindFalseNegative {
+			return m.word
 		}
 	}
-	// Remove false positives
-	for _, word := range g.falsePositives {
-		s = strings.Replace(s, word, "", -1)
-	}
 
-	if g.exactWord {
-		tokens := strings.Split(s, space)
-		for _, token := range tokens {
-			if sliceContains(g.profanities, token) {
-				return token
-			}
+	falsePositiveSpans := spansOfKind(matches, acKindFalsePositive)
+	sanitizedRunes := []rune(s)
+
+	for _, m := range matches {
+		if m.kind != acKindProfanity {
+			continue
 		}
-	} else {
-		// Check for profanities
-		for _, word := range g.profanities {
-			if match := strings.Contains(s, word); match {
-				return word
-			}
+		if overlapsAny(m.start, m.end, falsePositiveSpans) {
+			continue
+		}
+		if g.exactWord && !isWholeToken(sanitizedRunes, m.start, m.end) {
+			continue
 		}
+		return m.word
 	}
 	return ""
 }
 
-func sliceContains(words []string, s string) bool {
-	for _, word := range words {
-		if strings.EqualFold(s, word) {
-			return true
-		}
-	}
-	return false
+// Censor takes in a string (word or sentence) and tries to censor all profanities found.
+func (g *ProfanityDetector) Censor(s string) string {
+	return g.buildCensored([]rune(s), g.matchSpans(s))
 }
 
-func (g *ProfanityDetector) indexToRune(s string, index int) int {
-	count := 0
-	for i := range s {
-		if i == index {
-			break
+// matchSpans returns the original-rune spans Censor would replace in s: every profanity match, merged and
+// filtered against false positives and exactWord exactly as Censor and ExtractProfanity are. It's exposed
+// separately so streaming consumers (CensorWriter, ProfanityScanner) can compute spans over a whole buffer
+// and only commit the ones that land before their flush boundary.
+func (g *ProfanityDetector) matchSpans(s string) [][2]int {
+	sanitized, originalIndexes := g.sanitize(s, true)
+
+	matches := g.scanAll(sanitized)
+	falsePositiveSpans := spansOfKind(matches, acKindFalsePositive)
+	sanitizedRunes := []rune(sanitized)
+
+	var spans [][2]int
+	for _, m := range matches {
+		if m.kind != acKindFalseNegative {
+			if m.kind != acKindProfanity {
+				continue
+			}
+			if overlapsAny(m.start, m.end, falsePositiveSpans) {
+				continue
+			}
+			if g.exactWord && !isWholeToken(sanitizedRunes, m.start, m.end) {
+				continue
+			}
 		}
-		if i < index {
-			count++
+		if span, ok := originalSpan(originalIndexes, m.start, m.end); ok {
+			spans = append(spans, span)
 		}
 	}
-	return count
+	return mergeSpans(spans)
 }
 
-func (g *ProfanityDetector) Censor(s string) string {
-	censored := []rune(s)
-	var originalIndexes []int
-	s, originalIndexes = g.sanitize(s, true)
-	runeWordLength := 0
-
-	g.checkProfanity(&s, &originalIndexes, &censored, g.falseNegatives, &runeWordLength)
-	g.removeFalsePositives(&s, &originalIndexes, &runeWordLength)
-	g.checkProfanity(&s, &originalIndexes, &censored, g.profanities, &runeWordLength)
+// buildCensored applies spans (non-overlapping, sorted by start, as returned by matchSpans) to runes,
+// replacing each span per WithReplaceWholeWord/WithReplacementCharacter, and returns the result.
+func (g *ProfanityDetector) buildCensored(runes []rune, spans [][2]int) string {
+	if !g.replaceWholeWord {
+		out := append([]rune(nil), runes...)
+		for _, span := range spans {
+			for i := span[0]; i < span[1]; i++ {
+				out[i] = g.replacementCharacter
+			}
+		}
+		return string(out)
+	}
 
-	return string(censored)
+	const wholeWordMaskLength = 4
+	mask := strings.Repeat(string(g.replacementCharacter), wholeWordMaskLength)
+	var sb strings.Builder
+	cursor := 0
+	for _, span := range spans {
+		sb.WriteString(string(runes[cursor:span[0]]))
+		sb.WriteString(mask)
+		cursor = span[1]
+	}
+	sb.WriteString(string(runes[cursor:]))
+	return sb.String()
 }
 
-func (g *ProfanityDetector) checkProfanity(s *string, originalIndexes *[]int, censored *[]rune, wordList []string, runeWordLength *int) {
-	for _, word := range wordList {
-		currentIndex := 0
-		*runeWordLength = len([]rune(word))
-		for currentIndex != -1 {
-			if foundIndex := strings.Index((*s)[currentIndex:], word); foundIndex != -1 {
-				for i := 0; i < *runeWordLength; i++ {
-					runeIndex := g.indexToRune(*s, currentIndex+foundIndex) + i
-					if runeIndex < len(*originalIndexes) {
-						(*censored)[(*originalIndexes)[runeIndex]] = '*'
-					}
-				}
-				currentIndex += foundIndex + len([]byte(word))
-			} else {
-				break
-			}
-		}
+// originalSpan maps the sanitized-string rune span [start, end) back to a rune span into the original
+// input, using originalIndexes (sanitized rune index -> original rune index).
+func originalSpan(originalIndexes []int, start, end int) ([2]int, bool) {
+	if start < 0 || end > len(originalIndexes) || start >= end {
+		return [2]int{}, false
 	}
+	return [2]int{originalIndexes[start], originalIndexes[end-1] + 1}, true
 }
 
-func (g *ProfanityDetector) removeFalsePositives(s *string, originalIndexes *[]int, runeWordLength *int) {
-	for _, word := range g.falsePositives {
-		currentIndex := 0
-		*runeWordLength = len([]rune(word))
-		for currentIndex != -1 {
-			if foundIndex := strings.Index((*s)[currentIndex:], word); foundIndex != -1 {
-				foundRuneIndex := g.indexToRune(*s, foundIndex)
-				*originalIndexes = append((*originalIndexes)[:foundRuneIndex], (*originalIndexes)[foundRuneIndex+*runeWordLength:]...)
-				currentIndex += foundIndex + len([]byte(word))
-			} else {
-				break
+// mergeSpans sorts spans by start and merges any that overlap or touch, so Censor never double-replaces a
+// rune that multiple matches (e.g. an automaton match and a fuzzy match) agree on.
+func mergeSpans(spans [][2]int) [][2]int {
+	if len(spans) == 0 {
+		return spans
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span[0] <= last[1] {
+			if span[1] > last[1] {
+				last[1] = span[1]
 			}
+			continue
 		}
-		*s = strings.Replace(*s, word, "", -1)
+		merged = append(merged, span)
 	}
+	return merged
 }
 
 func (g ProfanityDetector) sanitize(s string, rememberOriginalIndexes bool) (string, []int) {
@@ -239,23 +394,35 @@ func (g ProfanityDetector) sanitize(s string, rememberOriginalIndexes bool) (str
 		s = strings.ReplaceAll(s, "()", "o")
 	}
 	sb := strings.Builder{}
+	obfuscationRun := 0
 	for _, char := range s {
 		if replacement, found := g.characterReplacements[char]; found {
 			if g.sanitizeSpecialCharacters && replacement == ' ' {
-				// If the replacement is a space, and we're sanitizing special characters speak, we replace.
-				sb.WriteRune(replacement)
+				obfuscationRun++
+				if g.obfuscationLength < 0 || obfuscationRun <= g.obfuscationLength {
+					// If the replacement is a space, and we're sanitizing special characters speak, we replace.
+					sb.WriteRune(replacement)
+					continue
+				}
+				// The run of filler characters between two kept letters is longer than
+				// WithObfuscationLength allows: keep the character as-is instead of collapsing it away, so
+				// the letters on either side of the run don't end up adjacent after sanitizeSpaces strips
+				// any remaining lone spaces.
+				sb.WriteRune(char)
 				continue
 			} else if g.sanitizeLeetSpeak && replacement != ' ' {
+				obfuscationRun = 0
 				// If the replacement isn't a space, and we're sanitizing leet speak, we replace.
 				sb.WriteRune(replacement)
 				continue
 			}
 		}
+		obfuscationRun = 0
 		sb.WriteRune(char)
 	}
 	s = sb.String()
 	if g.sanitizeAccents {
-		s = removeAccents(s)
+		s = removeAccents(s, g.firstSupportedRune, g.lastSupportedRune, g.preservedAccents)
 	}
 	var originalIndexes []int
 	if rememberOriginalIndexes {
@@ -272,18 +439,68 @@ func (g ProfanityDetector) sanitize(s string, rememberOriginalIndexes bool) (str
 	return s, originalIndexes
 }
 
+var removeAccentsTransformer = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
 // removeAccents strips all accents from characters.
-// Only called if ProfanityDetector.removeAccents is set to true
-func removeAccents(s string) string {
-	removeAccentsTransformer := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+// Only called if ProfanityDetector.sanitizeAccents is set to true.
+//
+// firstRune and lastRune bound the range of runes assumed to need no special handling (by default,
+// firstRuneSupported/lastRuneSupported, i.e. Latin text); WithLocales widens this range so that, e.g.,
+// Cyrillic text doesn't trigger the transform below for every single character. preserve lists runes that
+// must survive untouched because a locale treats them as letters in their own right rather than accented
+// variants of a base letter (e.g. Portuguese "ç", "ã" - without this, "ção" would be stripped to "cao").
+func removeAccents(s string, firstRune, lastRune rune, preserve map[rune]bool) string {
+	triggered := false
 	for _, character := range s {
+		if preserve[character] {
+			continue
+		}
 		// If there's a character outside the range of supported runes, there might be some accented words
-		if character < firstRuneSupported || character > lastRuneSupported {
-			s, _, _ = transform.String(removeAccentsTransformer, s)
+		if character < firstRune || character > lastRune {
+			triggered = true
 			break
 		}
 	}
-	return s
+	if !triggered {
+		return s
+	}
+	if len(preserve) == 0 {
+		transformed, _, _ := transform.String(removeAccentsTransformer, s)
+		return transformed
+	}
+
+	// Shield preserved runes behind Unicode Private Use Area placeholders so the NFD/Mn-removal transform
+	// can't touch them, then swap the originals back in once it has run.
+	shield := make(map[rune]rune, len(preserve))
+	unshield := make(map[rune]rune, len(preserve))
+	placeholder := rune(0xE000)
+	var shielded strings.Builder
+	for _, character := range s {
+		if preserve[character] {
+			p, ok := shield[character]
+			if !ok {
+				p = placeholder
+				shield[character] = p
+				unshield[p] = character
+				placeholder++
+			}
+			shielded.WriteRune(p)
+			continue
+		}
+		shielded.WriteRune(character)
+	}
+
+	transformed, _, _ := transform.String(removeAccentsTransformer, shielded.String())
+
+	var restored strings.Builder
+	for _, character := range transformed {
+		if original, ok := unshield[character]; ok {
+			restored.WriteRune(original)
+			continue
+		}
+		restored.WriteRune(character)
+	}
+	return restored.String()
 }
 
 // buildCharacterReplacements builds characterReplacements if WithSanitizeLeetSpeak or WithSanitizeSpecialCharacters is
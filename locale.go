@@ -0,0 +1,29 @@
+package goaway
+
+// Locale bundles a language's dictionaries and character-replacement table so a ProfanityDetector can be
+// extended to cover languages other than English. Locale packages such as goaway/locales/pt,
+// goaway/locales/ru, goaway/locales/es and goaway/locales/fr each expose one of these as a package-level
+// Locale value, meant to be passed to NewProfanityDetector or WithLocales.
+type Locale struct {
+	// Name identifies the locale, e.g. "pt", "ru", "es". The detector itself does not read it.
+	Name string
+
+	Profanities    []string
+	FalsePositives []string
+	FalseNegatives []string
+
+	// CharacterReplacements is merged into the detector's replacement table. Locale-specific leet-speak
+	// mappings (e.g. Cyrillic '0' -> 'о') are often entirely different from the Latin defaults.
+	CharacterReplacements map[rune]rune
+
+	// AccentedLetters lists runes that removeAccents must leave untouched for this locale because they are
+	// meaningful letters rather than accented variants of a base letter, e.g. Portuguese "ç"/"ã".
+	AccentedLetters []rune
+
+	// FirstRune and LastRune bound the range of runes this locale's alphabet natively uses. They widen
+	// firstRuneSupported/lastRuneSupported so that, e.g., Russian text doesn't trigger the (comparatively
+	// expensive) accent-removal transform for every single Cyrillic character. Leave unset (0) to not
+	// affect the supported range.
+	FirstRune rune
+	LastRune  rune
+}
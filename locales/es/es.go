@@ -0,0 +1,35 @@
+// Package es provides a Spanish profanity dictionary and character-replacement table for use with
+// goaway.NewProfanityDetector(es.Locale) or (*goaway.ProfanityDetector).WithLocales.
+package es
+
+import "github.com/ntietz/go-away"
+
+// Locale is the Spanish dictionary, character-replacement table and accent-preservation rules.
+var Locale = goaway.Locale{
+	Name: "es",
+
+	Profanities: []string{
+		"mierda",
+		"gilipollas",
+		"cabron",
+		"pendejo",
+		"joder",
+		"puta",
+	},
+
+	FalsePositives: []string{
+		"cabronazo", // affectionate nickname in several dialects, not necessarily profane
+	},
+
+	CharacterReplacements: map[rune]rune{
+		'4': 'a',
+		'3': 'e',
+		'1': 'i',
+		'0': 'o',
+		'5': 's',
+		'7': 't',
+	},
+
+	// "ñ" is a letter in its own right in Spanish, not an accented "n".
+	AccentedLetters: []rune{'ñ'},
+}
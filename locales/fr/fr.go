@@ -0,0 +1,34 @@
+// Package fr provides a French profanity dictionary and character-replacement table for use with
+// goaway.NewProfanityDetector(fr.Locale) or (*goaway.ProfanityDetector).WithLocales.
+package fr
+
+import "github.com/ntietz/go-away"
+
+// Locale is the French dictionary, character-replacement table and accent-preservation rules.
+var Locale = goaway.Locale{
+	Name: "fr",
+
+	Profanities: []string{
+		"merde",
+		"putain",
+		"connard",
+		"salope",
+		"enculé",
+		"bordel",
+	},
+
+	FalsePositives: []string{
+		"merdier", // colloquial for "a mess", not necessarily profane
+	},
+
+	CharacterReplacements: map[rune]rune{
+		'4': 'a',
+		'3': 'e',
+		'1': 'i',
+		'0': 'o',
+		'5': 's',
+		'7': 't',
+	},
+
+	AccentedLetters: []rune{'é', 'è', 'ê', 'ç', 'à', 'û'},
+}
@@ -0,0 +1,43 @@
+// Package pt provides a Portuguese profanity dictionary and character-replacement table for use with
+// goaway.NewProfanityDetector(pt.Locale) or (*goaway.ProfanityDetector).WithLocales.
+package pt
+
+import "github.com/ntietz/go-away"
+
+// Locale is the Portuguese dictionary, character-replacement table and accent-preservation rules.
+var Locale = goaway.Locale{
+	Name: "pt",
+
+	Profanities: []string{
+		"caralho",
+		"merda",
+		"porra",
+		"cacete",
+		"arrombado",
+		"desgraçado",
+		"cuzão",
+		"filho da puta",
+	},
+
+	FalsePositives: []string{
+		"cacetinho", // diminutive of "cacete", used colloquially for a small stick
+	},
+
+	FalseNegatives: []string{
+		"vsf", // common abbreviation of "vai se foder"
+	},
+
+	CharacterReplacements: map[rune]rune{
+		'4': 'a',
+		'3': 'e',
+		'1': 'i',
+		'0': 'o',
+		'5': 's',
+		'7': 't',
+	},
+
+	// "ç" and the nasal/accented vowels are letters in their own right in Portuguese, not accented
+	// variants of a base letter, so accent-stripping must leave them alone (e.g. "ção" must not become
+	// "cao").
+	AccentedLetters: []rune{'ç', 'ã', 'õ', 'á', 'à', 'â', 'é', 'ê', 'í', 'ó', 'ô', 'ú'},
+}
@@ -0,0 +1,38 @@
+// Package ru provides a Russian profanity dictionary and character-replacement table for use with
+// goaway.NewProfanityDetector(ru.Locale) or (*goaway.ProfanityDetector).WithLocales.
+package ru
+
+import "github.com/ntietz/go-away"
+
+// Locale is the Russian dictionary, character-replacement table and supported rune range.
+var Locale = goaway.Locale{
+	Name: "ru",
+
+	Profanities: []string{
+		"блядь",
+		"сука",
+		"хуй",
+		"пизда",
+		"ебать",
+		"мудак",
+	},
+
+	FalsePositives: []string{
+		"сукно", // fabric; shares a prefix with "сука" but is unrelated
+	},
+
+	CharacterReplacements: map[rune]rune{
+		'0': 'о',
+		'3': 'з',
+		'4': 'ч',
+		'6': 'б',
+		'$': 'с',
+		'@': 'а',
+	},
+
+	// Cyrillic text falls entirely outside the Latin range firstRuneSupported/lastRuneSupported cover;
+	// without widening it here, every Cyrillic rune would be treated as potentially accented and pay the
+	// cost of the NFD transform.
+	FirstRune: 0x0400,
+	LastRune:  0x04FF,
+}
@@ -0,0 +1,146 @@
+package goaway
+
+import "sort"
+
+// Match represents a single profanity occurrence found in an input string.
+type Match struct {
+	// Word is the profanity from the dictionary that was matched.
+	Word string
+	// MatchedText is the exact substring of the original input that triggered the match, e.g. "4sshol3".
+	MatchedText string
+	// StartByte and EndByte are the byte offsets of MatchedText within the original input.
+	StartByte int
+	EndByte   int
+	// StartRune and EndRune are the rune offsets of MatchedText within the original input.
+	StartRune int
+	EndRune   int
+}
+
+// Find takes in a string (word or sentence) and looks for the first profanity match.
+// Returns the match and true if one was found, or a zero-value Match and false otherwise.
+func (g *ProfanityDetector) Find(s string) (Match, bool) {
+	matches := g.FindAll(s)
+	if len(matches) == 0 {
+		return Match{}, false
+	}
+	return matches[0], true
+}
+
+// FindAll takes in a string (word or sentence) and looks for every profanity match.
+//
+// Unlike ExtractProfanity, which only returns the name of the first profanity found, FindAll returns every
+// match along with the exact substring of s that triggered it and its byte/rune offsets into s, which is
+// useful for highlighting matches in a UI or building a moderation log. It honors exactWord, falsePositives
+// and falseNegatives the same way ExtractProfanity and Censor do, and is built on the same Aho-Corasick scan.
+func (g *ProfanityDetector) FindAll(s string) []Match {
+	sanitized, originalIndexes := g.sanitize(s, true)
+	runeOffsets := runeToByteOffsets(s)
+
+	acMatches := g.scanAll(sanitized)
+	falsePositiveSpans := spansOfKind(acMatches, acKindFalsePositive)
+	sanitizedRunes := []rune(sanitized)
+
+	matches := make([]Match, 0, len(acMatches))
+	for _, m := range acMatches {
+		switch m.kind {
+		case acKindFalsePositive:
+			continue
+		case acKindProfanity:
+			if overlapsAny(m.start, m.end, falsePositiveSpans) {
+				continue
+			}
+			if g.exactWord && !isWholeToken(sanitizedRunes, m.start, m.end) {
+				continue
+			}
+		}
+		if match, ok := buildMatch(s, m.word, originalIndexes, runeOffsets, m.start, m.end); ok {
+			matches = append(matches, match)
+		}
+	}
+
+	matches = dedupeMatches(matches)
+	sort.Slice(matches, func(i, j int) bool { return matches[i].StartRune < matches[j].StartRune })
+	return matches
+}
+
+// dedupeMatches drops duplicate Match values that report the same word at the same span, which happens
+// when, e.g., WithFuzzyMatching is enabled and a word matches both the Aho-Corasick automaton and the fuzzy
+// tokenizer. It preserves the first occurrence of each (Word, StartRune, EndRune).
+func dedupeMatches(matches []Match) []Match {
+	type key struct {
+		word       string
+		start, end int
+	}
+	seen := make(map[key]bool, len(matches))
+	deduped := matches[:0]
+	for _, m := range matches {
+		k := key{m.Word, m.StartRune, m.EndRune}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		deduped = append(deduped, m)
+	}
+	return deduped
+}
+
+func overlapsAny(start, end int, spans [][2]int) bool {
+	for _, span := range spans {
+		if start < span[1] && span[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+// buildMatch maps a [sanitizedStart, sanitizedEnd) span of the sanitized string back to the original input
+// using originalIndexes (sanitized rune index -> original rune index) and runeOffsets (original rune index
+// -> original byte offset).
+func buildMatch(original, word string, originalIndexes, runeOffsets []int, sanitizedStart, sanitizedEnd int) (Match, bool) {
+	if sanitizedStart < 0 || sanitizedEnd > len(originalIndexes) || sanitizedStart >= sanitizedEnd {
+		return Match{}, false
+	}
+	startOriginalRune := originalIndexes[sanitizedStart]
+	endOriginalRune := originalIndexes[sanitizedEnd-1] + 1
+	startByte := runeOffsets[startOriginalRune]
+	endByte := runeOffsets[endOriginalRune]
+	return Match{
+		Word:        word,
+		MatchedText: original[startByte:endByte],
+		StartByte:   startByte,
+		EndByte:     endByte,
+		StartRune:   startOriginalRune,
+		EndRune:     endOriginalRune,
+	}, true
+}
+
+// runeToByteOffsets returns, for each rune index of s, the byte offset at which that rune begins, plus a
+// final entry for len(s) so that end offsets can always be looked up as offsets[runeIndex].
+func runeToByteOffsets(s string) []int {
+	offsets := make([]int, 0, len(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(s))
+	return offsets
+}
+
+// FindAll takes in a string (word or sentence) and looks for every profanity match.
+//
+// Uses the default ProfanityDetector
+func FindAll(s string) []Match {
+	if defaultProfanityDetector == nil {
+		defaultProfanityDetector = NewProfanityDetector()
+	}
+	return defaultProfanityDetector.FindAll(s)
+}
+
+// Find takes in a string (word or sentence) and looks for the first profanity match.
+//
+// Uses the default ProfanityDetector
+func Find(s string) (Match, bool) {
+	if defaultProfanityDetector == nil {
+		defaultProfanityDetector = NewProfanityDetector()
+	}
+	return defaultProfanityDetector.Find(s)
+}
@@ -0,0 +1,240 @@
+package goaway
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// maxDictionaryRuneLen returns the rune length of the longest entry across all of the detector's
+// dictionaries. This only bounds exact/automaton matches; see streamTailRuneLen for the tail size streaming
+// consumers retain, which also accounts for fuzzy matching.
+func (g *ProfanityDetector) maxDictionaryRuneLen() int {
+	max := 0
+	for _, list := range [][]string{g.profanities, g.falsePositives, g.falseNegatives} {
+		for _, word := range list {
+			if n := len([]rune(word)); n > max {
+				max = n
+			}
+		}
+	}
+	return max
+}
+
+// streamTailRuneLen returns how many trailing runes streaming consumers (CensorWriter, ProfanityScanner)
+// retain between chunks. An exact/automaton match covers at most maxDictionaryRuneLen runes of the
+// sanitized text. When WithFuzzyMatching is enabled, a token up to fuzzyMaxDistance runes longer than the
+// longest dictionary entry can still match it (see bestFuzzyMatch), so the tail is widened by that much.
+// The tail is counted in runes of the raw input, so a match whose raw text is longer than its sanitized
+// form (e.g. letters separated by filler characters or spaces the sanitizer removes) can still be split
+// across a chunk boundary, and is then not detected.
+func (g *ProfanityDetector) streamTailRuneLen() int {
+	tail := g.maxDictionaryRuneLen() - 1
+	if g.fuzzyMatching {
+		tail += g.fuzzyMaxDistance
+	}
+	return tail
+}
+
+// splitTail returns how many leading bytes of buf are safe to commit now. It holds back the trailing
+// tailRuneLen runes, since they could still turn out to be the prefix of a dictionary entry that continues
+// in the next chunk, unless atEOF, in which case the whole buffer is safe. This only bounds where a new
+// match could still grow from; callers must still scan the whole buffer (not just this prefix), since a
+// match can start before the cutoff and end after it.
+func splitTail(buf []byte, tailRuneLen int, atEOF bool) int {
+	if atEOF || tailRuneLen <= 0 {
+		return len(buf)
+	}
+	runes := []rune(string(buf))
+	if len(runes) <= tailRuneLen {
+		return 0
+	}
+	return len(string(runes[:len(runes)-tailRuneLen]))
+}
+
+// CensorWriter wraps an io.Writer, censoring profanities in the bytes written to it before forwarding them
+// on. Because a profanity can straddle two Write calls, it retains a tail buffer of at least
+// streamTailRuneLen runes between flushes, so Close must be called once writing is done to flush whatever
+// remains.
+type CensorWriter struct {
+	detector *ProfanityDetector
+	w        io.Writer
+	pending  []rune
+}
+
+// NewCensorWriter creates a CensorWriter that censors profanities (per g's dictionaries and configuration)
+// before forwarding bytes to w.
+func (g *ProfanityDetector) NewCensorWriter(w io.Writer) *CensorWriter {
+	return &CensorWriter{detector: g, w: w}
+}
+
+// NewCensorWriter creates a CensorWriter using the default ProfanityDetector.
+func NewCensorWriter(w io.Writer) *CensorWriter {
+	if defaultProfanityDetector == nil {
+		defaultProfanityDetector = NewProfanityDetector()
+	}
+	return defaultProfanityDetector.NewCensorWriter(w)
+}
+
+// Write buffers p and censors and forwards as much of the buffer as can't still be part of a profanity that
+// straddles a later Write. It always reports having consumed all of p, as required by io.Writer.
+func (cw *CensorWriter) Write(p []byte) (int, error) {
+	cw.pending = append(cw.pending, []rune(string(p))...)
+	if err := cw.flushSafe(false); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close censors and forwards whatever is left in the tail buffer. It must be called once the caller is done
+// writing, since Write always holds back up to streamTailRuneLen runes.
+func (cw *CensorWriter) Close() error {
+	return cw.flushSafe(true)
+}
+
+// flushSafe censors and forwards as much of cw.pending as can't still be part of a profanity straddling the
+// tail, retaining the rest for the next call. It scans the whole pending buffer (not just the committable
+// prefix) via matchSpans, so a match that starts before the cutoff but ends after it is still found; the
+// cutoff is then pulled back to that match's start so it's carried forward uncensored instead of split.
+func (cw *CensorWriter) flushSafe(atEOF bool) error {
+	if len(cw.pending) == 0 {
+		return nil
+	}
+
+	cutoff := len(cw.pending)
+	if !atEOF {
+		cutoff = len(cw.pending) - cw.detector.streamTailRuneLen()
+		if cutoff > len(cw.pending) {
+			cutoff = len(cw.pending)
+		}
+		if cutoff <= 0 {
+			return nil
+		}
+	}
+
+	spans := cw.detector.matchSpans(string(cw.pending))
+	var committed [][2]int
+	for _, span := range spans {
+		if span[0] >= cutoff {
+			continue
+		}
+		if span[1] > cutoff {
+			cutoff = span[0]
+			continue
+		}
+		committed = append(committed, span)
+	}
+
+	censored := cw.detector.buildCensored(cw.pending[:cutoff], committed)
+	cw.pending = cw.pending[cutoff:]
+	_, err := io.WriteString(cw.w, censored)
+	return err
+}
+
+// ProfanityScanner scans an io.Reader for profanities, yielding Match values with byte and rune offsets into
+// the original stream. It's used like a bufio.Scanner: call Scan in a loop, and Match to get the result of the
+// most recent successful call.
+type ProfanityScanner struct {
+	detector *ProfanityDetector
+	r        io.Reader
+
+	buf        []byte
+	baseOffset int64 // byte offset, into the original stream, of buf[0]
+	baseRune   int64 // rune offset, into the original stream, of buf[0]
+	readErr    error
+
+	queue   []Match
+	current Match
+}
+
+// NewProfanityScanner creates a ProfanityScanner that scans r for profanities per g's dictionaries and
+// configuration.
+func (g *ProfanityDetector) NewProfanityScanner(r io.Reader) *ProfanityScanner {
+	return &ProfanityScanner{detector: g, r: r}
+}
+
+// NewProfanityScanner creates a ProfanityScanner using the default ProfanityDetector.
+func NewProfanityScanner(r io.Reader) *ProfanityScanner {
+	if defaultProfanityDetector == nil {
+		defaultProfanityDetector = NewProfanityDetector()
+	}
+	return defaultProfanityDetector.NewProfanityScanner(r)
+}
+
+// scanChunkSize is how much is read from the underlying reader at a time.
+const scanChunkSize = 64 * 1024
+
+// Scan advances to the next Match, returning false once the stream is exhausted or Err returns non-nil.
+func (ps *ProfanityScanner) Scan() bool {
+	for len(ps.queue) == 0 {
+		if !ps.fill() {
+			return false
+		}
+	}
+	ps.current, ps.queue = ps.queue[0], ps.queue[1:]
+	return true
+}
+
+// Match returns the Match found by the most recent call to Scan.
+func (ps *ProfanityScanner) Match() Match {
+	return ps.current
+}
+
+// Err returns the first non-EOF error encountered while reading, if any.
+func (ps *ProfanityScanner) Err() error {
+	if ps.readErr == io.EOF {
+		return nil
+	}
+	return ps.readErr
+}
+
+// fill reads the next chunk (if the reader isn't exhausted), scans the entire retained buffer for matches,
+// and queues whichever of them can't still straddle the next chunk, with offsets adjusted to be relative to
+// the original stream. A match that starts before the cutoff but ends after it pulls the cutoff back to its
+// start, so it's retried whole against a future, larger buffer rather than missed or split. It reports
+// whether the caller should keep scanning.
+func (ps *ProfanityScanner) fill() bool {
+	if ps.readErr != nil && len(ps.buf) == 0 {
+		return false
+	}
+
+	if ps.readErr == nil {
+		chunk := make([]byte, scanChunkSize)
+		n, err := ps.r.Read(chunk)
+		ps.buf = append(ps.buf, chunk[:n]...)
+		if err != nil {
+			ps.readErr = err
+		}
+	}
+
+	atEOF := ps.readErr != nil
+	cutoff := splitTail(ps.buf, ps.detector.streamTailRuneLen(), atEOF)
+	if cutoff == 0 {
+		return !atEOF
+	}
+
+	matches := ps.detector.FindAll(string(ps.buf))
+	var committed []Match
+	for _, m := range matches {
+		if m.StartByte >= cutoff {
+			continue
+		}
+		if m.EndByte > cutoff {
+			cutoff = m.StartByte
+			continue
+		}
+		committed = append(committed, m)
+	}
+
+	for _, m := range committed {
+		m.StartByte += int(ps.baseOffset)
+		m.EndByte += int(ps.baseOffset)
+		m.StartRune += int(ps.baseRune)
+		m.EndRune += int(ps.baseRune)
+		ps.queue = append(ps.queue, m)
+	}
+	ps.baseOffset += int64(cutoff)
+	ps.baseRune += int64(utf8.RuneCount(ps.buf[:cutoff]))
+	ps.buf = ps.buf[cutoff:]
+
+	return true
+}